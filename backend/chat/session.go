@@ -0,0 +1,123 @@
+// Package chat keeps per-user Gemini ChatSessions alive in memory across
+// turns of POST /api/coach/chat, evicting sessions that have been idle past
+// a TTL.
+package chat
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/generative-ai-go/genai"
+
+	"github.com/HiKe945ReDX/NeroForge/backend/pkg/llm/memory"
+)
+
+// DefaultTTL is how long a session may sit idle before eviction.
+const DefaultTTL = 30 * time.Minute
+
+type entry struct {
+	client     *genai.Client
+	session    *genai.ChatSession
+	memory     *memory.Memory
+	lastAccess time.Time
+}
+
+// Store holds one ChatSession (and its token-budget Memory) per user,
+// evicting idle entries on access. Each session keeps its own genai.Client
+// alive (chat history lives on the session, not the client, but closing the
+// client would break the connection mid-conversation), so Store closes the
+// client on eviction.
+type Store struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	locks   map[string]*sync.Mutex
+	ttl     time.Duration
+}
+
+func NewStore(ttl time.Duration) *Store {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Store{entries: map[string]*entry{}, locks: map[string]*sync.Mutex{}, ttl: ttl}
+}
+
+// Get returns the session and its Memory for userID if one exists and
+// hasn't expired.
+func (s *Store) Get(userID string) (*genai.ChatSession, *memory.Memory, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evictLocked()
+
+	e, ok := s.entries[userID]
+	if !ok {
+		return nil, nil, false
+	}
+	e.lastAccess = time.Now()
+	return e.session, e.memory, true
+}
+
+// Put stores session and mem for userID (backed by client), closing any
+// session it replaces.
+func (s *Store) Put(userID string, client *genai.Client, session *genai.ChatSession, mem *memory.Memory) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.putLocked(userID, client, session, mem)
+}
+
+func (s *Store) putLocked(userID string, client *genai.Client, session *genai.ChatSession, mem *memory.Memory) {
+	if old, ok := s.entries[userID]; ok {
+		old.client.Close()
+	}
+	s.entries[userID] = &entry{client: client, session: session, memory: mem, lastAccess: time.Now()}
+}
+
+// GetOrCreate returns the existing session and Memory for userID, or calls
+// create to build a new one and stores it. create runs with userID's lock
+// held (but not the Store's), so it's safe to do slow client/model setup in
+// it; holding a per-user lock across the whole check-then-create-then-store
+// sequence is what keeps two concurrent first turns for the same new user
+// from both missing the cache and racing to Put, where the loser's Put would
+// close the winner's client out from under its in-flight request.
+func (s *Store) GetOrCreate(userID string, create func() (*genai.Client, *genai.ChatSession, *memory.Memory, error)) (*genai.ChatSession, *memory.Memory, error) {
+	lock := s.userLock(userID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if session, mem, ok := s.Get(userID); ok {
+		return session, mem, nil
+	}
+
+	client, session, mem, err := create()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.mu.Lock()
+	s.putLocked(userID, client, session, mem)
+	s.mu.Unlock()
+	return session, mem, nil
+}
+
+func (s *Store) userLock(userID string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.locks[userID]
+	if !ok {
+		l = &sync.Mutex{}
+		s.locks[userID] = l
+	}
+	return l
+}
+
+// evictLocked removes and closes entries idle past the TTL. Callers must
+// hold s.mu.
+func (s *Store) evictLocked() {
+	cutoff := time.Now().Add(-s.ttl)
+	for userID, e := range s.entries {
+		if e.lastAccess.Before(cutoff) {
+			e.client.Close()
+			delete(s.entries, userID)
+		}
+	}
+}
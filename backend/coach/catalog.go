@@ -0,0 +1,68 @@
+// Package coach routes a user's Big-Five (OCEAN) personality profile to the
+// best-matching coach persona, replacing the old cascading if/else in
+// selectCoach where later branches silently overwrote earlier ones.
+package coach
+
+// Profile is a user's OCEAN vector, each axis on a 0-100 scale.
+type Profile struct {
+	Openness          float64
+	Conscientiousness float64
+	Extraversion      float64
+	Agreeableness     float64
+	Neuroticism       float64
+}
+
+// Coach is a persona with a target OCEAN profile it's best suited for, and
+// the system prompt used to steer the model when this coach is active.
+type Coach struct {
+	ID          string
+	Name        string
+	Personality string
+	Emoji       string
+	Prompt      string
+	Target      Profile
+}
+
+// Catalog is the full set of coaches selectCoach routes between.
+var Catalog = []Coach{
+	{
+		ID: "1", Name: "Sarah", Personality: "Motivator", Emoji: "💪",
+		Prompt: "You are an energetic, uplifting coach who celebrates wins!",
+		Target: Profile{Openness: 75, Conscientiousness: 50, Extraversion: 85, Agreeableness: 70, Neuroticism: 30},
+	},
+	{
+		ID: "2", Name: "Marcus", Personality: "Strategist", Emoji: "🎯",
+		Prompt: "You are analytical, detail-oriented, structured.",
+		Target: Profile{Openness: 55, Conscientiousness: 90, Extraversion: 35, Agreeableness: 45, Neuroticism: 25},
+	},
+	{
+		ID: "3", Name: "Priya", Personality: "Visionary", Emoji: "🔭",
+		Prompt: "You are imaginative and big-picture, pushing the user to think beyond the obvious path.",
+		Target: Profile{Openness: 95, Conscientiousness: 45, Extraversion: 55, Agreeableness: 50, Neuroticism: 35},
+	},
+	{
+		ID: "4", Name: "Elena", Personality: "Nurturer", Emoji: "🌱",
+		Prompt: "You are warm, patient, and validating, focused on building the user's confidence.",
+		Target: Profile{Openness: 50, Conscientiousness: 55, Extraversion: 45, Agreeableness: 90, Neuroticism: 35},
+	},
+	{
+		ID: "5", Name: "Jordan", Personality: "Collaborator", Emoji: "🤝",
+		Prompt: "You are friendly, conversational, balanced.",
+		Target: Profile{Openness: 60, Conscientiousness: 60, Extraversion: 75, Agreeableness: 65, Neuroticism: 40},
+	},
+	{
+		ID: "6", Name: "Dana", Personality: "Challenger", Emoji: "🔥",
+		Prompt: "You are direct and unafraid to push back, challenging the user's assumptions to sharpen their thinking.",
+		Target: Profile{Openness: 65, Conscientiousness: 70, Extraversion: 60, Agreeableness: 30, Neuroticism: 30},
+	},
+	{
+		ID: "7", Name: "Tom", Personality: "Steady Hand", Emoji: "🪨",
+		Prompt: "You are calm and methodical, de-escalating anxiety and breaking problems into small steps.",
+		Target: Profile{Openness: 40, Conscientiousness: 75, Extraversion: 40, Agreeableness: 70, Neuroticism: 15},
+	},
+	{
+		ID: "8", Name: "Aisha", Personality: "Connector", Emoji: "🌐",
+		Prompt: "You emphasize networking and relationships, coaching the user toward people-driven opportunities.",
+		Target: Profile{Openness: 70, Conscientiousness: 55, Extraversion: 90, Agreeableness: 80, Neuroticism: 35},
+	},
+}
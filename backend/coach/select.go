@@ -0,0 +1,106 @@
+package coach
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Weights lets callers emphasize certain OCEAN axes when scoring matches.
+// A zero Weights is treated as all-axes-equal.
+type Weights struct {
+	Openness          float64
+	Conscientiousness float64
+	Extraversion      float64
+	Agreeableness     float64
+	Neuroticism       float64
+}
+
+func (w Weights) orDefault() Weights {
+	if w == (Weights{}) {
+		return Weights{1, 1, 1, 1, 1}
+	}
+	return w
+}
+
+// Match is one scored coach candidate for a user's profile.
+type Match struct {
+	Coach     Coach
+	Distance  float64
+	Rationale string
+}
+
+// TopMatches ranks Catalog by weighted Euclidean distance to profile
+// (lower is better) and returns the top n.
+func TopMatches(profile Profile, weights Weights, n int) []Match {
+	w := weights.orDefault()
+
+	matches := make([]Match, len(Catalog))
+	for i, c := range Catalog {
+		d := weightedDistance(profile, c.Target, w)
+		matches[i] = Match{Coach: c, Distance: d, Rationale: rationale(profile, c)}
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Distance < matches[j].Distance })
+
+	if n > len(matches) {
+		n = len(matches)
+	}
+	return matches[:n]
+}
+
+func weightedDistance(a, b Profile, w Weights) float64 {
+	sq := func(weight, x, y float64) float64 { d := x - y; return weight * d * d }
+	sum := sq(w.Openness, a.Openness, b.Openness) +
+		sq(w.Conscientiousness, a.Conscientiousness, b.Conscientiousness) +
+		sq(w.Extraversion, a.Extraversion, b.Extraversion) +
+		sq(w.Agreeableness, a.Agreeableness, b.Agreeableness) +
+		sq(w.Neuroticism, a.Neuroticism, b.Neuroticism)
+	return math.Sqrt(sum)
+}
+
+// rationale explains why a coach was a good match in terms of the user's
+// most distinctive (highest) trait and how it lines up with the coach.
+func rationale(p Profile, c Coach) string {
+	traits := []struct {
+		name  string
+		value float64
+	}{
+		{"openness", p.Openness},
+		{"conscientiousness", p.Conscientiousness},
+		{"extraversion", p.Extraversion},
+		{"agreeableness", p.Agreeableness},
+		{"neuroticism", p.Neuroticism},
+	}
+	sort.Slice(traits, func(i, j int) bool { return traits[i].value > traits[j].value })
+	top := traits[0]
+	return fmt.Sprintf("%s matches because your high %s (%.0f) aligns with their %s style", c.Name, top.name, top.value, c.Personality)
+}
+
+// store persists the last-selected coach per user so other endpoints (e.g.
+// mockInterview) can inject it without the caller re-sending the profile.
+var (
+	mu    sync.RWMutex
+	store = map[string]Coach{}
+)
+
+// Select scores profile against Catalog, persists the winner for userID,
+// and returns the top n matches (winner first).
+func Select(userID string, profile Profile, weights Weights, n int) []Match {
+	matches := TopMatches(profile, weights, n)
+	if len(matches) > 0 {
+		mu.Lock()
+		store[userID] = matches[0].Coach
+		mu.Unlock()
+	}
+	return matches
+}
+
+// Get returns the coach previously selected for userID, if any.
+func Get(userID string) (Coach, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	c, ok := store[userID]
+	return c, ok
+}
@@ -0,0 +1,110 @@
+package coach
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWeightedDistance(t *testing.T) {
+	a := Profile{Openness: 50, Conscientiousness: 50, Extraversion: 50, Agreeableness: 50, Neuroticism: 50}
+
+	if d := weightedDistance(a, a, Weights{}.orDefault()); d != 0 {
+		t.Errorf("distance to self = %v, want 0", d)
+	}
+
+	b := Profile{Openness: 60, Conscientiousness: 50, Extraversion: 50, Agreeableness: 50, Neuroticism: 50}
+	if got, want := weightedDistance(a, b, Weights{}.orDefault()), 10.0; got != want {
+		t.Errorf("distance = %v, want %v", got, want)
+	}
+
+	// Zeroing a trait's weight should drop its contribution entirely.
+	w := Weights{Openness: 0, Conscientiousness: 1, Extraversion: 1, Agreeableness: 1, Neuroticism: 1}
+	if got := weightedDistance(a, b, w); got != 0 {
+		t.Errorf("distance with openness weight 0 = %v, want 0", got)
+	}
+}
+
+func TestTopMatchesOrdersByDistanceAscending(t *testing.T) {
+	profile := Profile{Openness: 95, Conscientiousness: 45, Extraversion: 55, Agreeableness: 50, Neuroticism: 35}
+
+	matches := TopMatches(profile, Weights{}, 3)
+	if len(matches) != 3 {
+		t.Fatalf("len(matches) = %d, want 3", len(matches))
+	}
+	for i := 1; i < len(matches); i++ {
+		if matches[i].Distance < matches[i-1].Distance {
+			t.Errorf("matches not sorted ascending: [%d]=%v < [%d]=%v", i, matches[i].Distance, i-1, matches[i-1].Distance)
+		}
+	}
+	// Priya's Target is an exact match for this profile, so she should win.
+	if matches[0].Coach.Name != "Priya" {
+		t.Errorf("top match = %s, want Priya", matches[0].Coach.Name)
+	}
+}
+
+func TestTopMatchesClampsNToCatalogLength(t *testing.T) {
+	matches := TopMatches(Profile{}, Weights{}, 1000)
+	if len(matches) != len(Catalog) {
+		t.Errorf("len(matches) = %d, want %d (len(Catalog))", len(matches), len(Catalog))
+	}
+}
+
+func TestRationalePicksDominantTraitIncludingNeuroticism(t *testing.T) {
+	c := Catalog[0]
+
+	p := Profile{Openness: 10, Conscientiousness: 10, Extraversion: 10, Agreeableness: 10, Neuroticism: 90}
+	r := rationale(p, c)
+	if !strings.Contains(r, "neuroticism") {
+		t.Errorf("rationale(%+v) = %q, want it to mention neuroticism", p, r)
+	}
+
+	p2 := Profile{Openness: 90, Conscientiousness: 10, Extraversion: 10, Agreeableness: 10, Neuroticism: 10}
+	r2 := rationale(p2, c)
+	if !strings.Contains(r2, "openness") {
+		t.Errorf("rationale(%+v) = %q, want it to mention openness", p2, r2)
+	}
+}
+
+func TestSelectPersistsWinnerForGet(t *testing.T) {
+	profile := Profile{Openness: 95, Conscientiousness: 45, Extraversion: 55, Agreeableness: 50, Neuroticism: 35}
+	matches := Select("test-user-select", profile, Weights{}, 1)
+	if len(matches) == 0 {
+		t.Fatal("Select returned no matches")
+	}
+
+	got, ok := Get("test-user-select")
+	if !ok {
+		t.Fatal("Get found no coach after Select")
+	}
+	if got.ID != matches[0].Coach.ID {
+		t.Errorf("Get = %s, want persisted winner %s", got.ID, matches[0].Coach.ID)
+	}
+
+	if _, ok := Get("never-selected-user"); ok {
+		t.Error("Get found a coach for a user that never called Select")
+	}
+}
+
+func TestWeightsOrDefault(t *testing.T) {
+	if w := (Weights{}).orDefault(); w != (Weights{1, 1, 1, 1, 1}) {
+		t.Errorf("zero Weights.orDefault() = %+v, want all-ones", w)
+	}
+
+	custom := Weights{Openness: 2, Conscientiousness: 1, Extraversion: 1, Agreeableness: 1, Neuroticism: 1}
+	if w := custom.orDefault(); w != custom {
+		t.Errorf("non-zero Weights.orDefault() = %+v, want unchanged %+v", w, custom)
+	}
+}
+
+func TestWeightedDistanceSymmetric(t *testing.T) {
+	a := Profile{Openness: 20, Conscientiousness: 80, Extraversion: 40, Agreeableness: 60, Neuroticism: 10}
+	b := Profile{Openness: 90, Conscientiousness: 10, Extraversion: 70, Agreeableness: 30, Neuroticism: 55}
+	w := Weights{}.orDefault()
+
+	d1 := weightedDistance(a, b, w)
+	d2 := weightedDistance(b, a, w)
+	if math.Abs(d1-d2) > 1e-9 {
+		t.Errorf("weightedDistance not symmetric: %v vs %v", d1, d2)
+	}
+}
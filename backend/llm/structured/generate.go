@@ -0,0 +1,90 @@
+package structured
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// MaxRetries is how many times Generate will ask the model to correct a
+// failed call or a response that does not decode into the target type
+// before giving up.
+const MaxRetries = 3
+
+// ErrGenerateFailed is returned when the model still produced an invalid
+// response after MaxRetries corrections. RawText holds the last response
+// so callers can surface it for debugging.
+type ErrGenerateFailed struct {
+	RawText string
+	Cause   error
+}
+
+func (e *ErrGenerateFailed) Error() string {
+	return fmt.Sprintf("structured: failed after retries: %v", e.Cause)
+}
+
+func (e *ErrGenerateFailed) Unwrap() error { return e.Cause }
+
+// Generate prompts model with the given schema enforced via
+// GenerationConfig.ResponseSchema, unmarshals the result into out (a
+// pointer), and retries with the decode error fed back into the prompt on
+// failure. The decoded result is not checked against the schema's required
+// fields: a response that decodes but omits one is accepted.
+func Generate(ctx context.Context, model *genai.GenerativeModel, prompt string, out interface{}) error {
+	schema, err := SchemaFor(out)
+	if err != nil {
+		return fmt.Errorf("structured: deriving schema: %w", err)
+	}
+
+	model.GenerationConfig.ResponseMIMEType = "application/json"
+	model.GenerationConfig.ResponseSchema = schema
+
+	currentPrompt := prompt
+	var lastRaw string
+	var lastErr error
+
+	for attempt := 0; attempt <= MaxRetries; attempt++ {
+		resp, err := model.GenerateContent(ctx, genai.Text(currentPrompt))
+		if err != nil {
+			lastErr = err
+			currentPrompt = correctionPrompt(prompt, "", err)
+			continue
+		}
+
+		raw := extractText(resp)
+		lastRaw = raw
+
+		if err := json.Unmarshal([]byte(raw), out); err != nil {
+			lastErr = err
+			currentPrompt = correctionPrompt(prompt, raw, err)
+			continue
+		}
+
+		return nil
+	}
+
+	return &ErrGenerateFailed{RawText: lastRaw, Cause: lastErr}
+}
+
+func extractText(resp *genai.GenerateContentResponse) string {
+	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, part := range resp.Candidates[0].Content.Parts {
+		if text, ok := part.(genai.Text); ok {
+			sb.WriteString(string(text))
+		}
+	}
+	return sb.String()
+}
+
+func correctionPrompt(original, badOutput string, validationErr error) string {
+	return fmt.Sprintf(
+		"%s\n\nYour previous response was invalid: %v\nPrevious response:\n%s\n\nRespond again with ONLY valid JSON matching the required schema.",
+		original, validationErr, badOutput,
+	)
+}
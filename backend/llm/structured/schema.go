@@ -0,0 +1,127 @@
+package structured
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// Roadmap is the structured output shape for POST /api/ai/roadmap/generate.
+type Roadmap struct {
+	Phases []Phase `json:"phases" jsonschema:"description=Ordered phases of the roadmap,required"`
+}
+
+type Phase struct {
+	Week      int        `json:"week" jsonschema:"description=Week number this phase starts on,required"`
+	Tasks     []Task     `json:"tasks" jsonschema:"description=Tasks to complete during this phase,required"`
+	Resources []Resource `json:"resources" jsonschema:"description=Learning resources for this phase"`
+}
+
+type Task struct {
+	Title       string `json:"title" jsonschema:"description=Short task title,required"`
+	Description string `json:"description" jsonschema:"description=What to do and why"`
+}
+
+type Resource struct {
+	Title string `json:"title" jsonschema:"description=Resource title,required"`
+	URL   string `json:"url" jsonschema:"description=Link to the resource"`
+}
+
+// InterviewFeedback is the structured output shape for POST /api/interview/mock.
+type InterviewFeedback struct {
+	Score        int      `json:"score" jsonschema:"description=Score from 0-100,required"`
+	Strengths    []string `json:"strengths" jsonschema:"description=What the candidate did well,required"`
+	Improvements []string `json:"improvements" jsonschema:"description=Areas to work on,required"`
+	NextQuestion string   `json:"nextQuestion" jsonschema:"description=The next interview question to ask"`
+}
+
+// jsonTag holds the parsed pieces of a `jsonschema` struct tag.
+type jsonTag struct {
+	description string
+	required    bool
+}
+
+func parseJSONSchemaTag(tag string) jsonTag {
+	var jt jsonTag
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "required" {
+			jt.required = true
+			continue
+		}
+		if strings.HasPrefix(part, "description=") {
+			jt.description = strings.TrimPrefix(part, "description=")
+		}
+	}
+	return jt
+}
+
+// SchemaFor derives a *genai.Schema from a Go struct type via reflection,
+// reading field descriptions and required-ness from `jsonschema` tags.
+func SchemaFor(v interface{}) (*genai.Schema, error) {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return schemaForType(t)
+}
+
+func schemaForType(t reflect.Type) (*genai.Schema, error) {
+	switch t.Kind() {
+	case reflect.Struct:
+		return schemaForStruct(t)
+	case reflect.Slice, reflect.Array:
+		items, err := schemaForType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &genai.Schema{Type: genai.TypeArray, Items: items}, nil
+	case reflect.String:
+		return &genai.Schema{Type: genai.TypeString}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &genai.Schema{Type: genai.TypeInteger}, nil
+	case reflect.Float32, reflect.Float64:
+		return &genai.Schema{Type: genai.TypeNumber}, nil
+	case reflect.Bool:
+		return &genai.Schema{Type: genai.TypeBoolean}, nil
+	default:
+		return nil, fmt.Errorf("structured: unsupported kind %s", t.Kind())
+	}
+}
+
+func schemaForStruct(t reflect.Type) (*genai.Schema, error) {
+	props := make(map[string]*genai.Schema)
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+		name := field.Name
+		if jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ","); jsonName != "" {
+			name = jsonName
+		}
+
+		fieldSchema, err := schemaForType(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		jt := parseJSONSchemaTag(field.Tag.Get("jsonschema"))
+		fieldSchema.Description = jt.description
+		if jt.required {
+			required = append(required, name)
+		}
+
+		props[name] = fieldSchema
+	}
+
+	return &genai.Schema{
+		Type:       genai.TypeObject,
+		Properties: props,
+		Required:   required,
+	}, nil
+}
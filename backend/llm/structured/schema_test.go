@@ -0,0 +1,97 @@
+package structured
+
+import (
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestSchemaForPrimitives(t *testing.T) {
+	cases := []struct {
+		name string
+		in   interface{}
+		want genai.Type
+	}{
+		{"string", "", genai.TypeString},
+		{"int", 0, genai.TypeInteger},
+		{"float64", 0.0, genai.TypeNumber},
+		{"bool", false, genai.TypeBoolean},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			schema, err := SchemaFor(tc.in)
+			if err != nil {
+				t.Fatalf("SchemaFor(%v): %v", tc.in, err)
+			}
+			if schema.Type != tc.want {
+				t.Errorf("Type = %v, want %v", schema.Type, tc.want)
+			}
+		})
+	}
+}
+
+func TestSchemaForStructRequiredAndDescription(t *testing.T) {
+	schema, err := SchemaFor(Task{})
+	if err != nil {
+		t.Fatalf("SchemaFor(Task{}): %v", err)
+	}
+	if schema.Type != genai.TypeObject {
+		t.Fatalf("Type = %v, want TypeObject", schema.Type)
+	}
+
+	title, ok := schema.Properties["title"]
+	if !ok {
+		t.Fatal("missing \"title\" property")
+	}
+	if title.Description != "Short task title" {
+		t.Errorf("title.Description = %q, want %q", title.Description, "Short task title")
+	}
+
+	if len(schema.Required) != 1 || schema.Required[0] != "title" {
+		t.Errorf("Required = %v, want [title]", schema.Required)
+	}
+	if _, ok := schema.Properties["description"]; !ok {
+		t.Error("missing \"description\" property")
+	}
+}
+
+func TestSchemaForNestedSlice(t *testing.T) {
+	schema, err := SchemaFor(Roadmap{})
+	if err != nil {
+		t.Fatalf("SchemaFor(Roadmap{}): %v", err)
+	}
+
+	phases, ok := schema.Properties["phases"]
+	if !ok {
+		t.Fatal("missing \"phases\" property")
+	}
+	if phases.Type != genai.TypeArray {
+		t.Fatalf("phases.Type = %v, want TypeArray", phases.Type)
+	}
+	if phases.Items == nil || phases.Items.Type != genai.TypeObject {
+		t.Fatal("phases.Items should be a TypeObject schema for Phase")
+	}
+	if _, ok := phases.Items.Properties["tasks"]; !ok {
+		t.Error("Phase schema missing \"tasks\" property")
+	}
+}
+
+func TestSchemaForPointer(t *testing.T) {
+	schema, err := SchemaFor(&InterviewFeedback{})
+	if err != nil {
+		t.Fatalf("SchemaFor(&InterviewFeedback{}): %v", err)
+	}
+	if schema.Type != genai.TypeObject {
+		t.Errorf("Type = %v, want TypeObject", schema.Type)
+	}
+	if _, ok := schema.Properties["nextQuestion"]; !ok {
+		t.Error("missing \"nextQuestion\" property")
+	}
+}
+
+func TestSchemaForUnsupportedKind(t *testing.T) {
+	if _, err := SchemaFor(map[string]string{}); err == nil {
+		t.Error("expected an error for an unsupported kind (map), got nil")
+	}
+}
@@ -1,13 +1,131 @@
 package main
-import ("fmt"; "github.com/gin-gonic/gin"; "github.com/google/generative-ai-go/genai"; "context"; "encoding/json")
+import (
+  "fmt"
+  "os"
+  "errors"
+  "context"
+  "encoding/json"
+  "net/http"
+  "strings"
+  "github.com/google/generative-ai-go/genai"
+  "github.com/gin-gonic/gin"
+  "github.com/HiKe945ReDX/NeroForge/backend/chat"
+  "github.com/HiKe945ReDX/NeroForge/backend/coach"
+  "github.com/HiKe945ReDX/NeroForge/backend/llm/structured"
+  "github.com/HiKe945ReDX/NeroForge/backend/pkg/llm"
+  "github.com/HiKe945ReDX/NeroForge/backend/pkg/llm/memory"
+  "github.com/HiKe945ReDX/NeroForge/backend/tools"
+)
+
+// coachChatWindow is Gemini's context window budget for /api/coach/chat
+// sessions; rollup kicks in 4k tokens before the limit.
+var coachChatWindow = memory.Config{MaxTokens: 32000, SafetyMargin: 4000}
+
+var chatSessions = chat.NewStore(chat.DefaultTTL)
 func main() {
+  cfg, err := llm.LoadConfig(os.Getenv("LLM_CONFIG_FILE"))
+  if err != nil { panic(err) }
+  if err := llm.Init(cfg); err != nil { panic(err) }
+
+  tools.GetMarketData = func(args map[string]interface{}) (map[string]interface{}, error) {
+    career, _ := args["career"].(string)
+    return getMarketData(career), nil
+  }
+  tools.GetCareerDetail = func(args map[string]interface{}) (map[string]interface{}, error) {
+    id, _ := args["id"].(string)
+    return getCareerDetail(id), nil
+  }
+  tools.GetNews = func(args map[string]interface{}) (map[string]interface{}, error) {
+    career, _ := args["career"].(string)
+    return map[string]interface{}{"articles": getNews(career)}, nil
+  }
+
   r := gin.Default()
   r.POST("/api/careers/search", searchCareers)
   r.GET("/api/careers/:id", careerDetail)
   r.GET("/api/careers/:id/market", marketData)
   r.POST("/api/ai/roadmap/generate", generateRoadmap)
+  r.POST("/api/ai/roadmap/generate/stream", generateRoadmapStream)
+  r.POST("/api/interview/mock", mockInterview)
+  r.POST("/api/interview/mock/stream", mockInterviewStream)
+  r.POST("/api/coach/select", selectCoach)
+  r.POST("/api/coach/chat", coachChat)
+  r.GET("/api/llm/models", listModels)
   r.Run(":8080")
 }
+
+// resolveProvider honors the X-LLM-Provider header for per-request overrides,
+// falling back to the configured default provider. An explicit header naming
+// an unconfigured provider is reported back to the caller rather than
+// silently falling back, so a client pointed at a provider without an API
+// key gets a clear error instead of the default provider's output.
+func resolveProvider(c *gin.Context) (llm.Provider, error) {
+  if name := c.GetHeader("X-LLM-Provider"); name != "" {
+    return llm.Get(name)
+  }
+  if p := llm.Default(); p != nil {
+    return p, nil
+  }
+  return nil, fmt.Errorf("llm: no provider configured")
+}
+
+func listModels(c *gin.Context) {
+  provider, err := resolveProvider(c)
+  if err != nil { c.JSON(502, gin.H{"error": err.Error()}); return }
+  models, err := provider.Models(context.Background())
+  if err != nil { c.JSON(502, gin.H{"error": err.Error()}); return }
+  c.JSON(200, gin.H{"provider": provider.Name(), "models": models})
+}
+
+// writeSSE writes one Server-Sent Event. event may be empty for the default
+// "message" event type.
+func writeSSE(w http.ResponseWriter, event string, data interface{}) {
+  payload, _ := json.Marshal(data)
+  if event != "" {
+    fmt.Fprintf(w, "event: %s\n", event)
+  }
+  fmt.Fprintf(w, "data: %s\n\n", payload)
+  if flusher, ok := w.(http.Flusher); ok { flusher.Flush() }
+}
+
+// prepareSSE sets the headers required for a Server-Sent Events response.
+func prepareSSE(c *gin.Context) {
+  c.Header("Content-Type", "text/event-stream")
+  c.Header("Cache-Control", "no-cache")
+  c.Header("Connection", "keep-alive")
+}
+
+func generateRoadmapStream(c *gin.Context) {
+  var req map[string]interface{}; c.BindJSON(&req)
+  career, _ := req["career"].(string)
+  if career == "" { career = "Software Engineer" }
+
+  provider, err := resolveProvider(c)
+  if err != nil { c.JSON(502, gin.H{"error": err.Error()}); return }
+  opts := llm.Options{Model: c.GetHeader("X-LLM-Model")}
+  prompt := fmt.Sprintf("Generate a 12-week roadmap for a %s role. Respond with ONLY valid JSON with phases, tasks, resources.", career)
+
+  chunks, errs := provider.Stream(c.Request.Context(), []llm.Message{{Role: "user", Content: prompt}}, opts)
+
+  prepareSSE(c)
+  var full strings.Builder
+  for chunk := range chunks {
+    full.WriteString(chunk)
+    writeSSE(c.Writer, "", gin.H{"text": chunk})
+  }
+
+  if err, ok := <-errs; ok && err != nil {
+    writeSSE(c.Writer, "error", gin.H{"error": err.Error()})
+    return
+  }
+
+  var roadmap structured.Roadmap
+  if err := json.Unmarshal([]byte(full.String()), &roadmap); err != nil {
+    writeSSE(c.Writer, "error", gin.H{"error": "model did not return a valid roadmap", "raw": full.String()})
+    return
+  }
+  writeSSE(c.Writer, "done", gin.H{"roadmap": roadmap})
+}
 func searchCareers(c *gin.Context) {
   careers := []map[string]interface{}{
     {"id": "1", "title": "Software Engineer", "salary": "120-180k", "growth": "22%", "demand": "high", "skills": []string{"Python", "Go", "React"}},
@@ -16,72 +134,315 @@ func searchCareers(c *gin.Context) {
   c.JSON(200, careers)
 }
 func careerDetail(c *gin.Context) {
-  id := c.Param("id")
-  detail := map[string]interface{}{
+  c.JSON(200, getCareerDetail(c.Param("id")))
+}
+
+// getCareerDetail is the data behind GET /api/careers/:id, pulled out of the
+// handler so the tools package can expose it to the model as get_career_detail.
+func getCareerDetail(id string) map[string]interface{} {
+  return map[string]interface{}{
     "id": id, "title": "Software Engineer", "description": "Build scalable systems",
     "salary": map[string]interface{}{"entry": "90k", "mid": "140k", "senior": "200k"},
     "skills": []string{"Python", "Go", "Docker", "K8s"}, "education": "Bachelor's+", "companies": []string{"Google", "Meta", "Amazon"},
   }
-  c.JSON(200, detail)
 }
+
 func marketData(c *gin.Context) {
-  market := map[string]interface{}{
+  c.JSON(200, getMarketData(c.Param("id")))
+}
+
+// getMarketData is the data behind GET /api/careers/:id/market, pulled out of
+// the handler so the tools package can expose it to the model as get_market_data.
+func getMarketData(career string) map[string]interface{} {
+  return map[string]interface{}{
     "avgSalary": 145000, "openings": 5234, "trend": "📈 +22%", "growthProjection": "5yr: +28%",
     "topCompanies": []map[string]interface{}{{"name": "Google", "hiring": 234}, {"name": "Meta", "hiring": 187}},
   }
-  c.JSON(200, market)
 }
 func generateRoadmap(c *gin.Context) {
+  var req map[string]interface{}; c.BindJSON(&req)
+  career, _ := req["career"].(string)
+  if career == "" { career = "Software Engineer" }
+
   ctx := context.Background()
-  client, _ := genai.NewClient(ctx, genai.WithAPIKey("AIzaSyXXXXXXXXX")) // Use GEMINI_API_KEY env var
-  defer client.Close()
-  model := client.GenerativeModel("gemini-2.0-flash")
-  prompt := "Generate a 12-week roadmap for a Software Engineer role. Output JSON with phases, tasks, resources."
-  resp, _ := model.GenerateContent(ctx, genai.Text(prompt))
-  c.JSON(200, gin.H{"roadmap": resp.Candidates[0].Content})
+  provider, err := resolveProvider(c)
+  if err != nil { c.JSON(502, gin.H{"error": err.Error()}); return }
+  opts := llm.Options{Model: c.GetHeader("X-LLM-Model")}
+  prompt := fmt.Sprintf("Generate a 12-week roadmap for a %s role. Output JSON with phases, tasks, resources.", career)
+
+  // Gemini is the only provider with native ResponseSchema support today;
+  // everything else falls back to plain-text generation + best-effort JSON.
+  if gem, ok := provider.(*llm.Gemini); ok {
+    client, model, err := gem.ModelFor(ctx, opts)
+    if err != nil { c.JSON(502, gin.H{"error": "llm client unavailable"}); return }
+    defer client.Close()
+
+    var roadmap structured.Roadmap
+    if err := structured.Generate(ctx, model, prompt, &roadmap); err != nil {
+      var genErr *structured.ErrGenerateFailed
+      if errors.As(err, &genErr) {
+        c.JSON(502, gin.H{"error": "model did not return a valid roadmap", "raw": genErr.RawText})
+        return
+      }
+      c.JSON(502, gin.H{"error": "model did not return a valid roadmap"})
+      return
+    }
+    c.JSON(200, gin.H{"roadmap": roadmap})
+    return
+  }
+
+  text, err := provider.Generate(ctx, []llm.Message{{Role: "user", Content: prompt}}, opts)
+  if err != nil { c.JSON(502, gin.H{"error": err.Error()}); return }
+  var roadmap structured.Roadmap
+  if err := json.Unmarshal([]byte(text), &roadmap); err != nil {
+    c.JSON(502, gin.H{"error": "model did not return a valid roadmap", "raw": text})
+    return
+  }
+  c.JSON(200, gin.H{"roadmap": roadmap})
 }
 // PHASE 4: Coach Selection
-type Coach struct { ID string; Name string; Personality string; Emoji string; Prompt string }
 func selectCoach(c *gin.Context) {
-  var user map[string]interface{}; c.BindJSON(&user); 
-  openness := user["openness"].(float64); conscientiousness := user["conscientiousness"].(float64); extraversion := user["extraversion"].(float64)
-  var coach Coach
-  if openness > 70 { coach = Coach{"1", "Sarah", "Motivator", "💪", "You are an energetic, uplifting coach who celebrates wins!"} }
-  if conscientiousness > 70 { coach = Coach{"2", "Marcus", "Strategist", "🎯", "You are analytical, detail-oriented, structured."} }
-  if extraversion > 70 { coach = Coach{"5", "Jordan", "Collaborator", "🤝", "You are friendly, conversational, balanced."} }
-  c.JSON(200, coach)
+  var req map[string]interface{}; c.BindJSON(&req)
+  userID, _ := req["userId"].(string)
+  profile := coach.Profile{
+    Openness:          req["openness"].(float64),
+    Conscientiousness: req["conscientiousness"].(float64),
+    Extraversion:      req["extraversion"].(float64),
+    Agreeableness:     req["agreeableness"].(float64),
+    Neuroticism:       req["neuroticism"].(float64),
+  }
+
+  matches := coach.Select(userID, profile, coach.Weights{}, 3)
+  c.JSON(200, gin.H{"matches": matches})
+}
+
+// interviewPrompt builds the interview prompt, injecting the system prompt
+// of whichever coach was selected for userID (if any) via selectCoach.
+func interviewPrompt(userID, career, transcript, instruction string) string {
+  base := fmt.Sprintf("You are a %s interviewer. User answer: %s. %s", career, transcript, instruction)
+  if c, ok := coach.Get(userID); ok {
+    return fmt.Sprintf("%s\n\n%s", c.Prompt, base)
+  }
+  return base
 }
 
 // PHASE 5: Mock Interview with Voice
 func mockInterview(c *gin.Context) {
   var req map[string]interface{}; c.BindJSON(&req)
+  userID, _ := req["userId"].(string)
   career := req["career"].(string); transcript := req["transcript"].(string)
-  ctx := context.Background(); client, _ := genai.NewClient(ctx, genai.WithAPIKey(os.Getenv("GEMINI_API_KEY")))
-  model := client.GenerativeModel("gemini-2.0-flash")
-  prompt := fmt.Sprintf("You are a %s interviewer. User answer: %s. Ask next question or provide feedback.", career, transcript)
-  resp, _ := model.GenerateContent(ctx, genai.Text(prompt))
-  c.JSON(200, gin.H{
-    "feedback": resp.Candidates[0].Content.Parts[0],
-    "score": rand.Intn(40) + 60,
-    "strengths": []string{"Communication", "Problem-solving"},
-    "improvements": []string{"Technical depth", "System design"},
+
+  ctx := context.Background()
+  provider, err := resolveProvider(c)
+  if err != nil { c.JSON(502, gin.H{"error": err.Error()}); return }
+  opts := llm.Options{Model: c.GetHeader("X-LLM-Model")}
+  prompt := interviewPrompt(userID, career, transcript, "Score the answer 0-100, list strengths, list improvements, and give the next question.")
+
+  if gem, ok := provider.(*llm.Gemini); ok {
+    // Gemini can't combine ResponseSchema-structured output with tool use in
+    // a single call, so ground the feedback with a separate tool-enabled
+    // call first and fold the result into the structured prompt, rather than
+    // let the structured call hallucinate salaries and openings.
+    if grounding, gErr := groundWithTools(ctx, gem, opts, fmt.Sprintf(
+      "Use your tools to gather current market data, career detail, and recent news for %s, then summarize the key facts in 2-3 sentences.",
+      career,
+    )); gErr == nil && grounding != "" {
+      prompt = fmt.Sprintf("%s\n\nGround your feedback in this real data:\n%s", prompt, grounding)
+    }
+
+    client, model, err := gem.ModelFor(ctx, opts)
+    if err != nil { c.JSON(502, gin.H{"error": "llm client unavailable"}); return }
+    defer client.Close()
+
+    var feedback structured.InterviewFeedback
+    if err := structured.Generate(ctx, model, prompt, &feedback); err != nil {
+      var genErr *structured.ErrGenerateFailed
+      if errors.As(err, &genErr) {
+        c.JSON(502, gin.H{"error": "model did not return valid feedback", "raw": genErr.RawText})
+        return
+      }
+      c.JSON(502, gin.H{"error": "model did not return valid feedback"})
+      return
+    }
+    c.JSON(200, feedback)
+    return
+  }
+
+  text, err := provider.Generate(ctx, []llm.Message{{Role: "user", Content: prompt}}, opts)
+  if err != nil { c.JSON(502, gin.H{"error": err.Error()}); return }
+  var feedback structured.InterviewFeedback
+  if err := json.Unmarshal([]byte(text), &feedback); err != nil {
+    c.JSON(502, gin.H{"error": "model did not return valid feedback", "raw": text})
+    return
+  }
+  c.JSON(200, feedback)
+}
+
+func mockInterviewStream(c *gin.Context) {
+  var req map[string]interface{}; c.BindJSON(&req)
+  userID, _ := req["userId"].(string)
+  career := req["career"].(string); transcript := req["transcript"].(string)
+
+  ctx := c.Request.Context()
+  provider, err := resolveProvider(c)
+  if err != nil { c.JSON(502, gin.H{"error": err.Error()}); return }
+  opts := llm.Options{Model: c.GetHeader("X-LLM-Model")}
+  prompt := interviewPrompt(userID, career, transcript, "Score the answer 0-100, list strengths, list improvements, and give the next question. Respond with ONLY valid JSON.")
+
+  if gem, ok := provider.(*llm.Gemini); ok {
+    if grounding, gErr := groundWithTools(ctx, gem, opts, fmt.Sprintf(
+      "Use your tools to gather current market data, career detail, and recent news for %s, then summarize the key facts in 2-3 sentences.",
+      career,
+    )); gErr == nil && grounding != "" {
+      prompt = fmt.Sprintf("%s\n\nGround your feedback in this real data:\n%s", prompt, grounding)
+    }
+  }
+
+  chunks, errs := provider.Stream(ctx, []llm.Message{{Role: "user", Content: prompt}}, opts)
+
+  prepareSSE(c)
+  var full strings.Builder
+  for chunk := range chunks {
+    full.WriteString(chunk)
+    writeSSE(c.Writer, "", gin.H{"text": chunk})
+  }
+
+  if err, ok := <-errs; ok && err != nil {
+    writeSSE(c.Writer, "error", gin.H{"error": err.Error()})
+    return
+  }
+
+  var feedback structured.InterviewFeedback
+  if err := json.Unmarshal([]byte(full.String()), &feedback); err != nil {
+    writeSSE(c.Writer, "error", gin.H{"error": "model did not return valid feedback", "raw": full.String()})
+    return
+  }
+  writeSSE(c.Writer, "done", gin.H{"feedback": feedback})
+}
+
+// maxToolTurns bounds the function-calling loop so a misbehaving model can't
+// spin forever calling tools without ever producing a final answer.
+const maxToolTurns = 5
+
+// coachChat holds a multi-turn conversation with tool access to the
+// career/market/news handlers, so coaching advice is grounded in real data
+// instead of the model hallucinating salaries and openings.
+func coachChat(c *gin.Context) {
+  var req map[string]interface{}; c.BindJSON(&req)
+  userID, _ := req["userId"].(string)
+  message, _ := req["message"].(string)
+
+  provider, err := resolveProvider(c)
+  if err != nil { c.JSON(502, gin.H{"error": err.Error()}); return }
+  gem, ok := provider.(*llm.Gemini)
+  if !ok { c.JSON(502, gin.H{"error": "coach chat requires the gemini provider"}); return }
+
+  ctx := c.Request.Context()
+  session, mem, err := chatSessions.GetOrCreate(userID, func() (*genai.Client, *genai.ChatSession, *memory.Memory, error) {
+    client, model, err := gem.ModelFor(ctx, llm.Options{Model: c.GetHeader("X-LLM-Model")})
+    if err != nil { return nil, nil, nil, err }
+    model.Tools = tools.Declarations()
+    if coachPersona, ok := coach.Get(userID); ok {
+      model.SystemInstruction = &genai.Content{Parts: []genai.Part{genai.Text(coachPersona.Prompt)}}
+    }
+    session := model.StartChat()
+    mem := memory.New(gem, coachChatWindow, gem)
+    return client, session, mem, nil
   })
+  if err != nil { c.JSON(502, gin.H{"error": "llm client unavailable"}); return }
+
+  if err := mem.Append(ctx, "user", message); err != nil { c.JSON(502, gin.H{"error": err.Error()}); return }
+
+  reply, err := runToolLoop(ctx, session, genai.Text(message))
+  if err != nil { c.JSON(502, gin.H{"error": err.Error()}); return }
+
+  if err := mem.Append(ctx, "model", reply); err != nil { c.JSON(502, gin.H{"error": err.Error()}); return }
+  syncSessionHistory(session, mem)
+
+  c.JSON(200, gin.H{"reply": reply})
+}
+
+// syncSessionHistory replaces the ChatSession's own history with mem's
+// (possibly rolled-up) view, so a summarization actually shrinks what gets
+// sent to the model on the next turn instead of just being bookkeeping.
+func syncSessionHistory(session *genai.ChatSession, mem *memory.Memory) {
+  messages := mem.Messages()
+  history := make([]*genai.Content, len(messages))
+  for i, msg := range messages {
+    role := msg.Role
+    if role != "user" { role = "model" } // Gemini history only knows user/model
+    history[i] = &genai.Content{Role: role, Parts: []genai.Part{genai.Text(msg.Content)}}
+  }
+  session.History = history
+}
+
+// groundWithTools runs a short-lived tool-enabled chat turn so callers that
+// can't attach tools to their own call (e.g. a ResponseSchema-structured
+// generation, which Gemini can't combine with tool use) can still fold real
+// data into their prompt instead of leaving the model to hallucinate it.
+func groundWithTools(ctx context.Context, gem *llm.Gemini, opts llm.Options, query string) (string, error) {
+  client, model, err := gem.ModelFor(ctx, opts)
+  if err != nil { return "", err }
+  defer client.Close()
+
+  model.Tools = tools.Declarations()
+  session := model.StartChat()
+  return runToolLoop(ctx, session, genai.Text(query))
+}
+
+// runToolLoop sends parts to the session and, as long as the model responds
+// with function calls instead of text, dispatches them and feeds the results
+// back until a final text turn (or maxToolTurns is hit).
+func runToolLoop(ctx context.Context, session *genai.ChatSession, parts ...genai.Part) (string, error) {
+  for turn := 0; turn < maxToolTurns; turn++ {
+    resp, err := session.SendMessage(ctx, parts...)
+    if err != nil { return "", fmt.Errorf("coach chat: %w", err) }
+    if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+      return "", fmt.Errorf("coach chat: empty response")
+    }
+
+    var text strings.Builder
+    var calls []genai.FunctionCall
+    for _, part := range resp.Candidates[0].Content.Parts {
+      switch p := part.(type) {
+      case genai.Text:
+        text.WriteString(string(p))
+      case genai.FunctionCall:
+        calls = append(calls, p)
+      }
+    }
+
+    if len(calls) == 0 {
+      return text.String(), nil
+    }
+
+    parts = nil
+    for _, call := range calls {
+      result, err := tools.Dispatch(call.Name, call.Args)
+      if err != nil { result = map[string]interface{}{"error": err.Error()} }
+      parts = append(parts, genai.FunctionResponse{Name: call.Name, Response: result})
+    }
+  }
+  return "", fmt.Errorf("coach chat: exceeded %d tool-calling turns", maxToolTurns)
 }
 
 // PHASE 6: News Feed
 func newsFeed(c *gin.Context) {
-  career := c.Query("career")
-  articles := []map[string]interface{}{
+  c.JSON(200, getNews(c.Query("career")))
+}
+
+// getNews is the data behind the newsFeed handler (which main does not
+// route), pulled out of the handler so the tools package can expose it to
+// the model as get_news.
+func getNews(career string) []map[string]interface{} {
+  return []map[string]interface{}{
     {"title": fmt.Sprintf("%s Salary Up 12%% in Q4", career), "source": "LinkedIn Pulse", "summary": "Market trends show demand surge", "date": "Today"},
     {"title": "15,000 Job Openings Posted", "source": "Indeed", "summary": fmt.Sprintf("%s roles in high demand", career), "date": "Yesterday"},
     {"title": "New Skill: Kubernetes Now Required", "source": "Stack Overflow", "summary": "DevOps trend analysis", "date": "2 days ago"},
   }
-  c.JSON(200, articles)
 }
 
 func init() {
   // Register new endpoints in main()
-  // r.POST("/api/coach/select", selectCoach)
-  // r.POST("/api/interview/mock", mockInterview)
   // r.GET("/api/news", newsFeed)
 }
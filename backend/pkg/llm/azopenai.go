@@ -0,0 +1,145 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// AzureOpenAI implements Provider against an Azure OpenAI deployment. Unlike
+// OpenAI, the model is fixed per-deployment and auth uses an api-key header
+// plus an api-version query parameter.
+type AzureOpenAI struct {
+	apiKey     string
+	endpoint   string
+	deployment string
+	apiVersion string
+}
+
+func NewAzureOpenAI(cfg AzOpenAIConfig) *AzureOpenAI {
+	return &AzureOpenAI{
+		apiKey:     cfg.APIKey,
+		endpoint:   strings.TrimSuffix(cfg.Endpoint, "/"),
+		deployment: cfg.Deployment,
+		apiVersion: cfg.APIVersion,
+	}
+}
+
+func (a *AzureOpenAI) Name() string { return "azopenai" }
+
+func (a *AzureOpenAI) deploymentFor(opts Options) string {
+	if opts.Model != "" {
+		return opts.Model
+	}
+	return a.deployment
+}
+
+func (a *AzureOpenAI) url(deployment string) string {
+	version := a.apiVersion
+	if version == "" {
+		version = "2024-06-01"
+	}
+	return fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s", a.endpoint, deployment, version)
+}
+
+func (a *AzureOpenAI) newRequest(ctx context.Context, body chatCompletionRequest) (*http.Request, error) {
+	payload, _ := json.Marshal(body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url(body.Model), bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("azopenai: building request: %w", err)
+	}
+	req.Header.Set("api-key", a.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+func (a *AzureOpenAI) Generate(ctx context.Context, messages []Message, opts Options) (string, error) {
+	req, err := a.newRequest(ctx, chatCompletionRequest{Model: a.deploymentFor(opts), Messages: toChatMessages(messages)})
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("azopenai: request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("azopenai: unexpected status %d", resp.StatusCode)
+	}
+
+	var out chatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("azopenai: decoding response: %w", err)
+	}
+	if len(out.Choices) == 0 {
+		return "", fmt.Errorf("azopenai: empty response")
+	}
+	return out.Choices[0].Message.Content, nil
+}
+
+func (a *AzureOpenAI) Stream(ctx context.Context, messages []Message, opts Options) (<-chan string, <-chan error) {
+	chunks := make(chan string)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(chunks)
+		defer close(errs)
+
+		req, err := a.newRequest(ctx, chatCompletionRequest{Model: a.deploymentFor(opts), Messages: toChatMessages(messages), Stream: true})
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			errs <- fmt.Errorf("azopenai: request: %w", err)
+			return
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			errs <- fmt.Errorf("azopenai: unexpected status %d", resp.StatusCode)
+			return
+		}
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+			payload := strings.TrimPrefix(line, "data: ")
+			if payload == "[DONE]" {
+				return
+			}
+			var chunk chatCompletionResponse
+			if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+				continue
+			}
+			if len(chunk.Choices) > 0 {
+				chunks <- chunk.Choices[0].Delta.Content
+			}
+		}
+	}()
+
+	return chunks, errs
+}
+
+func (a *AzureOpenAI) Models(ctx context.Context) ([]string, error) {
+	// Azure OpenAI model availability is determined by deployments, which
+	// are managed outside this API; expose the configured deployment only.
+	return []string{a.deployment}, nil
+}
+
+func toChatMessages(messages []Message) []chatMessage {
+	out := make([]chatMessage, len(messages))
+	for i, m := range messages {
+		out[i] = chatMessage{Role: m.Role, Content: m.Content}
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package llm
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the top-level LLM config, loaded from an optional YAML file
+// and overridable with environment variables (env wins).
+type Config struct {
+	Provider string         `yaml:"provider"`
+	Gemini   GeminiConfig   `yaml:"gemini"`
+	OpenAI   OpenAIConfig   `yaml:"openai"`
+	AzOpenAI AzOpenAIConfig `yaml:"azopenai"`
+}
+
+type GeminiConfig struct {
+	APIKey       string `yaml:"api_key"`
+	DefaultModel string `yaml:"default_model"`
+}
+
+type OpenAIConfig struct {
+	APIKey       string `yaml:"api_key"`
+	DefaultModel string `yaml:"default_model"`
+}
+
+type AzOpenAIConfig struct {
+	APIKey       string `yaml:"api_key"`
+	Endpoint     string `yaml:"endpoint"`
+	Deployment   string `yaml:"deployment"`
+	APIVersion   string `yaml:"api_version"`
+	DefaultModel string `yaml:"default_model"`
+}
+
+// LoadConfig reads path if it exists (missing file is not an error, since
+// env vars alone are a valid config) and then applies env overrides from
+// LLM_PROVIDER, GEMINI_API_KEY, OPENAI_API_KEY, AZURE_OPENAI_API_KEY,
+// AZURE_OPENAI_ENDPOINT, and AZURE_OPENAI_DEPLOYMENT.
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return cfg, fmt.Errorf("llm: reading config %s: %w", path, err)
+		}
+		if err == nil {
+			if err := yaml.Unmarshal(data, &cfg); err != nil {
+				return cfg, fmt.Errorf("llm: parsing config %s: %w", path, err)
+			}
+		}
+	}
+
+	if v := os.Getenv("LLM_PROVIDER"); v != "" {
+		cfg.Provider = v
+	}
+	if v := os.Getenv("GEMINI_API_KEY"); v != "" {
+		cfg.Gemini.APIKey = v
+	}
+	if v := os.Getenv("OPENAI_API_KEY"); v != "" {
+		cfg.OpenAI.APIKey = v
+	}
+	if v := os.Getenv("AZURE_OPENAI_API_KEY"); v != "" {
+		cfg.AzOpenAI.APIKey = v
+	}
+	if v := os.Getenv("AZURE_OPENAI_ENDPOINT"); v != "" {
+		cfg.AzOpenAI.Endpoint = v
+	}
+	if v := os.Getenv("AZURE_OPENAI_DEPLOYMENT"); v != "" {
+		cfg.AzOpenAI.Deployment = v
+	}
+
+	if cfg.Provider == "" {
+		cfg.Provider = "gemini"
+	}
+	if cfg.Gemini.DefaultModel == "" {
+		cfg.Gemini.DefaultModel = "gemini-2.0-flash"
+	}
+	if cfg.OpenAI.DefaultModel == "" {
+		cfg.OpenAI.DefaultModel = "gpt-4o-mini"
+	}
+	if cfg.AzOpenAI.DefaultModel == "" {
+		cfg.AzOpenAI.DefaultModel = cfg.AzOpenAI.Deployment
+	}
+
+	return cfg, nil
+}
+
+// Init loads cfg's providers into the default registry and activates
+// cfg.Provider. Call this once at startup.
+//
+// Only providers with the credentials they need are registered: registering
+// an unconfigured provider would let a client select it (e.g. via
+// X-LLM-Provider) and only discover the problem several calls deep, as an
+// opaque 401 from the provider's API. Skipping registration instead makes
+// SetActive (and llm.Get, for per-request overrides) fail immediately with a
+// clear "no provider registered" error.
+func Init(cfg Config) error {
+	if cfg.Gemini.APIKey != "" {
+		Register(NewGemini(cfg.Gemini))
+	}
+	if cfg.OpenAI.APIKey != "" {
+		Register(NewOpenAI(cfg.OpenAI))
+	}
+	if cfg.AzOpenAI.APIKey != "" && cfg.AzOpenAI.Endpoint != "" && cfg.AzOpenAI.Deployment != "" {
+		Register(NewAzureOpenAI(cfg.AzOpenAI))
+	}
+	if len(List()) == 0 {
+		return fmt.Errorf("llm: no provider configured (set GEMINI_API_KEY, OPENAI_API_KEY, or AZURE_OPENAI_* env vars)")
+	}
+	return SetActive(cfg.Provider)
+}
@@ -0,0 +1,148 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/iterator"
+)
+
+// Gemini implements Provider against Google's Gemini API.
+type Gemini struct {
+	apiKey       string
+	defaultModel string
+}
+
+func NewGemini(cfg GeminiConfig) *Gemini {
+	return &Gemini{apiKey: cfg.APIKey, defaultModel: cfg.DefaultModel}
+}
+
+func (g *Gemini) Name() string { return "gemini" }
+
+// ModelFor returns a live genai client + model configured per opts, for
+// callers that need Gemini-specific features (e.g. ResponseSchema) beyond
+// the generic Provider interface. The caller owns closing the client.
+func (g *Gemini) ModelFor(ctx context.Context, opts Options) (*genai.Client, *genai.GenerativeModel, error) {
+	return g.model(ctx, opts)
+}
+
+func (g *Gemini) model(ctx context.Context, opts Options) (*genai.Client, *genai.GenerativeModel, error) {
+	client, err := genai.NewClient(ctx, genai.WithAPIKey(g.apiKey))
+	if err != nil {
+		return nil, nil, fmt.Errorf("gemini: new client: %w", err)
+	}
+	modelName := opts.Model
+	if modelName == "" {
+		modelName = g.defaultModel
+	}
+	model := client.GenerativeModel(modelName)
+	if opts.Temperature != 0 {
+		model.SetTemperature(opts.Temperature)
+	}
+	return client, model, nil
+}
+
+func (g *Gemini) Generate(ctx context.Context, messages []Message, opts Options) (string, error) {
+	client, model, err := g.model(ctx, opts)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	resp, err := model.GenerateContent(ctx, genai.Text(joinMessages(messages)))
+	if err != nil {
+		return "", fmt.Errorf("gemini: generate: %w", err)
+	}
+	return textFromResponse(resp), nil
+}
+
+func (g *Gemini) Stream(ctx context.Context, messages []Message, opts Options) (<-chan string, <-chan error) {
+	chunks := make(chan string)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(chunks)
+		defer close(errs)
+
+		client, model, err := g.model(ctx, opts)
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer client.Close()
+
+		iter := model.GenerateContentStream(ctx, genai.Text(joinMessages(messages)))
+		for {
+			resp, err := iter.Next()
+			if err != nil {
+				if err != iterator.Done {
+					errs <- err
+				}
+				return
+			}
+			chunks <- textFromResponse(resp)
+		}
+	}()
+
+	return chunks, errs
+}
+
+// CountTokens implements memory.TokenCounter using Gemini's native counting
+// API, so pkg/llm/memory doesn't have to fall back to its char/4 heuristic.
+func (g *Gemini) CountTokens(ctx context.Context, text string) (int, error) {
+	client, model, err := g.model(ctx, Options{})
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	resp, err := model.CountTokens(ctx, genai.Text(text))
+	if err != nil {
+		return 0, fmt.Errorf("gemini: count tokens: %w", err)
+	}
+	return int(resp.TotalTokens), nil
+}
+
+func (g *Gemini) Models(ctx context.Context) ([]string, error) {
+	client, err := genai.NewClient(ctx, genai.WithAPIKey(g.apiKey))
+	if err != nil {
+		return nil, fmt.Errorf("gemini: new client: %w", err)
+	}
+	defer client.Close()
+
+	var names []string
+	iter := client.ListModels(ctx)
+	for {
+		m, err := iter.Next()
+		if err != nil {
+			if err != iterator.Done {
+				return names, err
+			}
+			break
+		}
+		names = append(names, m.Name)
+	}
+	return names, nil
+}
+
+func joinMessages(messages []Message) string {
+	out := ""
+	for _, m := range messages {
+		out += m.Role + ": " + m.Content + "\n"
+	}
+	return out
+}
+
+func textFromResponse(resp *genai.GenerateContentResponse) string {
+	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+		return ""
+	}
+	out := ""
+	for _, part := range resp.Candidates[0].Content.Parts {
+		if text, ok := part.(genai.Text); ok {
+			out += string(text)
+		}
+	}
+	return out
+}
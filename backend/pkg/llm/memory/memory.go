@@ -0,0 +1,192 @@
+// Package memory provides a token-budget-aware conversation buffer: once
+// cumulative usage crosses a configured threshold, it summarizes the oldest
+// half of the conversation and replaces those turns with a single summary
+// message, so long-running sessions (e.g. /api/coach/chat) don't blow past
+// the provider's context window.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/HiKe945ReDX/NeroForge/backend/pkg/llm"
+)
+
+// TokenCounter estimates the token cost of a string. Providers that expose a
+// native counting API (e.g. Gemini's CountTokens) should implement this;
+// Memory falls back to a char/4 heuristic when none is given or it errors.
+type TokenCounter interface {
+	CountTokens(ctx context.Context, text string) (int, error)
+}
+
+// Config controls when Memory compresses the oldest turns of a conversation.
+type Config struct {
+	// MaxTokens is the provider's context window size, e.g. 32000.
+	MaxTokens int
+	// SafetyMargin is headroom reserved below MaxTokens; rollup triggers
+	// once usage crosses MaxTokens-SafetyMargin.
+	SafetyMargin int
+}
+
+func (cfg Config) threshold() int {
+	t := cfg.MaxTokens - cfg.SafetyMargin
+	if t < 0 {
+		return 0
+	}
+	return t
+}
+
+// Memory is a token-budget-aware conversation buffer for a single session.
+type Memory struct {
+	mu       sync.Mutex
+	provider llm.Provider
+	counter  TokenCounter
+	cfg      Config
+	messages []llm.Message
+	rollups  int
+	version  int
+}
+
+// New returns a Memory that summarizes via provider and, when counter is
+// non-nil, uses it for exact token counts instead of the char/4 heuristic.
+func New(provider llm.Provider, cfg Config, counter TokenCounter) *Memory {
+	return &Memory{provider: provider, cfg: cfg, counter: counter}
+}
+
+// Append adds a turn and rolls up the oldest half of the conversation if
+// the running total is now over budget.
+func (m *Memory) Append(ctx context.Context, role, content string) error {
+	m.mu.Lock()
+	m.messages = append(m.messages, llm.Message{Role: role, Content: content})
+	m.version++
+	snapshot := append([]llm.Message(nil), m.messages...)
+	ver := m.version
+	m.mu.Unlock()
+
+	tokens, err := m.countTokens(ctx, snapshot)
+	if err != nil {
+		return err
+	}
+	if tokens <= m.cfg.threshold() {
+		return nil
+	}
+	return m.rollup(ctx, snapshot, tokens, ver)
+}
+
+// Messages returns a copy of the current (possibly rolled-up) conversation.
+func (m *Memory) Messages() []llm.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]llm.Message(nil), m.messages...)
+}
+
+// Stats reports current usage for tuning rollup thresholds.
+type Stats struct {
+	Tokens   int
+	Messages int
+	Rollups  int
+}
+
+func (m *Memory) Stats(ctx context.Context) (Stats, error) {
+	m.mu.Lock()
+	messages := append([]llm.Message(nil), m.messages...)
+	rollups := m.rollups
+	m.mu.Unlock()
+
+	tokens, err := m.countTokens(ctx, messages)
+	return Stats{Tokens: tokens, Messages: len(messages), Rollups: rollups}, err
+}
+
+func (m *Memory) countTokens(ctx context.Context, messages []llm.Message) (int, error) {
+	text := joinContent(messages)
+	if m.counter != nil {
+		if n, err := m.counter.CountTokens(ctx, text); err == nil {
+			return n, nil
+		}
+	}
+	return len(text) / 4, nil
+}
+
+func (m *Memory) rollup(ctx context.Context, messages []llm.Message, tokens int, ver int) error {
+	// The summary is surfaced as a "user" turn (Gemini requires history to
+	// both alternate user/model *and* start on "user"), so the retained
+	// suffix must start on "model" for the combined [summary, rest...]
+	// sequence to keep alternating. Snap the split back to the nearest
+	// "model" turn so that holds.
+	half := len(messages) / 2
+	for half > 0 && messages[half].Role != "model" {
+		half--
+	}
+	if half == 0 {
+		return nil
+	}
+	oldest, rest := messages[:half], messages[half:]
+
+	summary, err := m.summarize(ctx, oldest)
+	if err != nil {
+		return fmt.Errorf("memory: rollup: %w", err)
+	}
+
+	summaryMsg := llm.Message{Role: "user", Content: "Here is a summary of our earlier conversation: " + summary}
+	newMessages := append([]llm.Message{summaryMsg}, rest...)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.version != ver {
+		// Another Append/rollup ran since our snapshot was taken. If it was
+		// a plain append (our snapshot is still a prefix of m.messages),
+		// graft the messages that arrived after our snapshot onto the end
+		// of our compacted result instead of dropping them. If it was a
+		// concurrent rollup instead (m.messages is no longer an extension
+		// of our snapshot), someone else already compacted the
+		// conversation; discard our now-stale result rather than clobber
+		// theirs.
+		if len(m.messages) < len(messages) || !sameTurns(m.messages[:len(messages)], messages) {
+			return nil
+		}
+		newMessages = append(newMessages, m.messages[len(messages):]...)
+	}
+
+	m.messages = newMessages
+	m.version++
+	m.rollups++
+	rollupNum := m.rollups
+
+	log.Printf("llm/memory: rolled up conversation at ~%d tokens (rollup #%d): %d turns -> %d turns", tokens, rollupNum, len(messages), len(newMessages))
+	return nil
+}
+
+func sameTurns(a, b []llm.Message) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Memory) summarize(ctx context.Context, turns []llm.Message) (string, error) {
+	prompt := fmt.Sprintf(
+		"Summarize the following conversation turns, preserving facts, decisions, and user goals:\n\n%s",
+		joinContent(turns),
+	)
+	return m.provider.Generate(ctx, []llm.Message{{Role: "user", Content: prompt}}, llm.Options{})
+}
+
+func joinContent(messages []llm.Message) string {
+	var sb strings.Builder
+	for _, msg := range messages {
+		sb.WriteString(msg.Role)
+		sb.WriteString(": ")
+		sb.WriteString(msg.Content)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,164 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/HiKe945ReDX/NeroForge/backend/pkg/llm"
+)
+
+// fakeProvider's Generate echoes the prompt it was given (which embeds the
+// turns being summarized), so tests can verify summarized content survives a
+// rollup without needing a real LLM call.
+type fakeProvider struct{}
+
+func (fakeProvider) Name() string { return "fake" }
+
+func (fakeProvider) Generate(ctx context.Context, messages []llm.Message, opts llm.Options) (string, error) {
+	if len(messages) == 0 {
+		return "", nil
+	}
+	return messages[0].Content, nil
+}
+
+func (fakeProvider) Stream(ctx context.Context, messages []llm.Message, opts llm.Options) (<-chan string, <-chan error) {
+	chunks := make(chan string)
+	errs := make(chan error)
+	close(chunks)
+	close(errs)
+	return chunks, errs
+}
+
+func (fakeProvider) Models(ctx context.Context) ([]string, error) { return nil, nil }
+
+// fakeCounter reports a fixed token count regardless of text, so tests can
+// force (or suppress) a rollup deterministically.
+type fakeCounter struct{ tokens int }
+
+func (f fakeCounter) CountTokens(ctx context.Context, text string) (int, error) {
+	return f.tokens, nil
+}
+
+func newMemory(tokens int) *Memory {
+	return New(fakeProvider{}, Config{MaxTokens: 100, SafetyMargin: 10}, fakeCounter{tokens: tokens})
+}
+
+func TestAppendNoRollupBelowThreshold(t *testing.T) {
+	m := newMemory(10) // well under the 90-token threshold
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		role := "user"
+		if i%2 == 1 {
+			role = "model"
+		}
+		if err := m.Append(ctx, role, fmt.Sprintf("turn %d", i)); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	if got := len(m.Messages()); got != 4 {
+		t.Errorf("len(Messages()) = %d, want 4 (no rollup expected below threshold)", got)
+	}
+}
+
+func TestRollupKeepsHistoryStartingOnUserAndAlternating(t *testing.T) {
+	m := newMemory(1000) // always over threshold, so every Append after the first rolls up
+	ctx := context.Background()
+	for i := 0; i < 6; i++ {
+		role := "user"
+		if i%2 == 1 {
+			role = "model"
+		}
+		if err := m.Append(ctx, role, fmt.Sprintf("turn %d", i)); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	messages := m.Messages()
+	if len(messages) == 0 {
+		t.Fatal("Messages() is empty")
+	}
+	if messages[0].Role != "user" {
+		t.Fatalf(`messages[0].Role = %q, want "user" (Gemini requires history to start on user)`, messages[0].Role)
+	}
+	for i := 1; i < len(messages); i++ {
+		if messages[i].Role == messages[i-1].Role {
+			t.Errorf("messages[%d] and messages[%d] are both %q; history must alternate user/model", i-1, i, messages[i].Role)
+		}
+	}
+}
+
+func TestRollupNoOpWhenNoModelTurnToSplitOn(t *testing.T) {
+	m := newMemory(1000)
+	// A lone user turn has no "model" turn to snap the split to, so rollup
+	// should leave it alone rather than discard it.
+	if err := m.Append(context.Background(), "user", "only turn"); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	messages := m.Messages()
+	if len(messages) != 1 || messages[0].Content != "only turn" {
+		t.Errorf("Messages() = %+v, want the single turn preserved", messages)
+	}
+}
+
+func TestConcurrentAppendDoesNotDropMessages(t *testing.T) {
+	m := newMemory(95) // just over threshold, so rollup is likely on most turns
+	ctx := context.Background()
+	const n = 20
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			role := "user"
+			if i%2 == 1 {
+				role = "model"
+			}
+			if err := m.Append(ctx, role, fmt.Sprintf("unique-turn-%d", i)); err != nil {
+				t.Errorf("Append: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var all strings.Builder
+	for _, msg := range m.Messages() {
+		all.WriteString(msg.Content)
+		all.WriteString("\n")
+	}
+	joined := all.String()
+	for i := 0; i < n; i++ {
+		want := fmt.Sprintf("unique-turn-%d", i)
+		if !strings.Contains(joined, want) {
+			t.Errorf("turn %q missing from final conversation after concurrent appends/rollups", want)
+		}
+	}
+}
+
+func TestStatsReportsRollupCount(t *testing.T) {
+	m := newMemory(1000)
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		role := "user"
+		if i%2 == 1 {
+			role = "model"
+		}
+		if err := m.Append(ctx, role, fmt.Sprintf("turn %d", i)); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	stats, err := m.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.Rollups == 0 {
+		t.Error("Stats().Rollups = 0, want at least one rollup given an always-over-threshold counter")
+	}
+	if stats.Messages != len(m.Messages()) {
+		t.Errorf("Stats().Messages = %d, want %d (len(Messages()))", stats.Messages, len(m.Messages()))
+	}
+}
@@ -0,0 +1,156 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OpenAI implements Provider against the OpenAI chat completions API.
+type OpenAI struct {
+	apiKey       string
+	defaultModel string
+	baseURL      string
+}
+
+func NewOpenAI(cfg OpenAIConfig) *OpenAI {
+	return &OpenAI{apiKey: cfg.APIKey, defaultModel: cfg.DefaultModel, baseURL: "https://api.openai.com/v1"}
+}
+
+func (o *OpenAI) Name() string { return "openai" }
+
+type chatCompletionRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+	Stream   bool          `json:"stream"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Delta   chatMessage `json:"delta"`
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+func (o *OpenAI) model(opts Options) string {
+	if opts.Model != "" {
+		return opts.Model
+	}
+	return o.defaultModel
+}
+
+func (o *OpenAI) Generate(ctx context.Context, messages []Message, opts Options) (string, error) {
+	body, _ := json.Marshal(chatCompletionRequest{Model: o.model(opts), Messages: toChatMessages(messages)})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("openai: building request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("openai: request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("openai: unexpected status %d", resp.StatusCode)
+	}
+
+	var out chatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("openai: decoding response: %w", err)
+	}
+	if len(out.Choices) == 0 {
+		return "", fmt.Errorf("openai: empty response")
+	}
+	return out.Choices[0].Message.Content, nil
+}
+
+func (o *OpenAI) Stream(ctx context.Context, messages []Message, opts Options) (<-chan string, <-chan error) {
+	chunks := make(chan string)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(chunks)
+		defer close(errs)
+
+		body, _ := json.Marshal(chatCompletionRequest{Model: o.model(opts), Messages: toChatMessages(messages), Stream: true})
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.baseURL+"/chat/completions", bytes.NewReader(body))
+		if err != nil {
+			errs <- fmt.Errorf("openai: building request: %w", err)
+			return
+		}
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			errs <- fmt.Errorf("openai: request: %w", err)
+			return
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			errs <- fmt.Errorf("openai: unexpected status %d", resp.StatusCode)
+			return
+		}
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+			payload := strings.TrimPrefix(line, "data: ")
+			if payload == "[DONE]" {
+				return
+			}
+			var chunk chatCompletionResponse
+			if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+				continue
+			}
+			if len(chunk.Choices) > 0 {
+				chunks <- chunk.Choices[0].Delta.Content
+			}
+		}
+	}()
+
+	return chunks, errs
+}
+
+func (o *OpenAI) Models(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.baseURL+"/models", nil)
+	if err != nil {
+		return nil, fmt.Errorf("openai: building request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+o.apiKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("openai: request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("openai: decoding response: %w", err)
+	}
+	names := make([]string, len(out.Data))
+	for i, m := range out.Data {
+		names[i] = m.ID
+	}
+	return names, nil
+}
@@ -0,0 +1,33 @@
+// Package llm provides a provider-agnostic interface over the LLM backends
+// this service can talk to (Gemini, OpenAI, Azure OpenAI), selected at
+// startup from config and overridable per-request.
+package llm
+
+import "context"
+
+// Message is one turn in a conversation passed to a Provider.
+type Message struct {
+	Role    string // "system", "user", or "assistant"; Gemini-facing callers (e.g. pkg/llm/memory) use "model" for assistant turns
+	Content string
+}
+
+// Options controls a single Generate/Stream call. Model is optional; when
+// empty, the provider uses its configured default model.
+type Options struct {
+	Model       string
+	Temperature float32
+}
+
+// Provider is implemented by every LLM backend this service supports.
+type Provider interface {
+	// Name returns the provider's registry key, e.g. "gemini".
+	Name() string
+	// Generate returns the full completion text for the given messages.
+	Generate(ctx context.Context, messages []Message, opts Options) (string, error)
+	// Stream returns a channel of incremental text chunks. The channel is
+	// closed when generation finishes; a send on the error channel ends the
+	// stream.
+	Stream(ctx context.Context, messages []Message, opts Options) (<-chan string, <-chan error)
+	// Models lists the model names this provider currently exposes.
+	Models(ctx context.Context) ([]string, error)
+}
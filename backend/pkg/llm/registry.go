@@ -0,0 +1,64 @@
+package llm
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Registry resolves provider names to Provider implementations and tracks
+// which one is active by default.
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]Provider
+	active    string
+}
+
+var defaultRegistry = &Registry{providers: map[string]Provider{}}
+
+// Register adds a provider to the default registry under its Name().
+func Register(p Provider) {
+	defaultRegistry.mu.Lock()
+	defer defaultRegistry.mu.Unlock()
+	defaultRegistry.providers[p.Name()] = p
+}
+
+// SetActive selects which registered provider Default() returns.
+func SetActive(name string) error {
+	defaultRegistry.mu.Lock()
+	defer defaultRegistry.mu.Unlock()
+	if _, ok := defaultRegistry.providers[name]; !ok {
+		return fmt.Errorf("llm: no provider registered as %q", name)
+	}
+	defaultRegistry.active = name
+	return nil
+}
+
+// Default returns the active provider, or nil if none has been selected.
+func Default() Provider {
+	defaultRegistry.mu.RLock()
+	defer defaultRegistry.mu.RUnlock()
+	return defaultRegistry.providers[defaultRegistry.active]
+}
+
+// Get returns the provider registered under name, for per-request overrides
+// (e.g. the X-LLM-Provider header).
+func Get(name string) (Provider, error) {
+	defaultRegistry.mu.RLock()
+	defer defaultRegistry.mu.RUnlock()
+	p, ok := defaultRegistry.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("llm: no provider registered as %q", name)
+	}
+	return p, nil
+}
+
+// List returns the names of all registered providers.
+func List() []string {
+	defaultRegistry.mu.RLock()
+	defer defaultRegistry.mu.RUnlock()
+	names := make([]string, 0, len(defaultRegistry.providers))
+	for name := range defaultRegistry.providers {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,86 @@
+// Package tools declares the internal REST handlers this service already
+// exposes (career detail, market data, news) as Gemini function-calling
+// tools, so a chat model can pull real numbers instead of hallucinating them.
+package tools
+
+import (
+	"fmt"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// Handler is a tool function's implementation: it takes the model's
+// arguments and returns the result to hand back as a FunctionResponse.
+type Handler func(args map[string]interface{}) (map[string]interface{}, error)
+
+// GetCareerDetail, GetMarketData, and GetNews are wired to the equivalent
+// backend data functions by main() at startup, since tools can't import the
+// main package.
+var (
+	GetCareerDetail Handler
+	GetMarketData   Handler
+	GetNews         Handler
+)
+
+// Declarations returns the Gemini tool declarations for the registered
+// handlers, for GenerativeModel.Tools.
+func Declarations() []*genai.Tool {
+	return []*genai.Tool{{
+		FunctionDeclarations: []*genai.FunctionDeclaration{
+			{
+				Name:        "get_market_data",
+				Description: "Get current market salary, openings, and growth data for a career.",
+				Parameters: &genai.Schema{
+					Type: genai.TypeObject,
+					Properties: map[string]*genai.Schema{
+						"career": {Type: genai.TypeString, Description: "Career title, e.g. 'Software Engineer'"},
+					},
+					Required: []string{"career"},
+				},
+			},
+			{
+				Name:        "get_career_detail",
+				Description: "Get detailed information about a career by id: description, skills, education, salary bands.",
+				Parameters: &genai.Schema{
+					Type: genai.TypeObject,
+					Properties: map[string]*genai.Schema{
+						"id": {Type: genai.TypeString, Description: "Career id"},
+					},
+					Required: []string{"id"},
+				},
+			},
+			{
+				Name:        "get_news",
+				Description: "Get recent news articles relevant to a career.",
+				Parameters: &genai.Schema{
+					Type: genai.TypeObject,
+					Properties: map[string]*genai.Schema{
+						"career": {Type: genai.TypeString, Description: "Career title, e.g. 'Software Engineer'"},
+					},
+					Required: []string{"career"},
+				},
+			},
+		},
+	}}
+}
+
+// Dispatch runs the named tool against args and returns its result.
+func Dispatch(name string, args map[string]interface{}) (map[string]interface{}, error) {
+	switch name {
+	case "get_market_data":
+		return call(GetMarketData, args)
+	case "get_career_detail":
+		return call(GetCareerDetail, args)
+	case "get_news":
+		return call(GetNews, args)
+	default:
+		return nil, fmt.Errorf("tools: unknown tool %q", name)
+	}
+}
+
+func call(h Handler, args map[string]interface{}) (map[string]interface{}, error) {
+	if h == nil {
+		return nil, fmt.Errorf("tools: handler not registered")
+	}
+	return h(args)
+}